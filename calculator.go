@@ -14,31 +14,60 @@ type TimeCorrection map[Target]time.Duration
 // AngleCorrection is value in degree, used to correct hour angle
 type AngleCorrection map[Target]float64
 
+// sunriseSunsetAngle is the standard solar depression angle used to mark
+// the edge of the solar disc at sunrise/sunset, after accounting for
+// atmospheric refraction and the sun's apparent radius.
+const sunriseSunsetAngle = 0.833
+
+// defaultImsakDuration is used for Imsak when neither ImsakAngle nor
+// ImsakDuration is set.
+const defaultImsakDuration = 10 * time.Minute
+
 // Calculator is calculator that used to calculate the prayer times.
 type Calculator struct {
-	Latitude          float64
-	Longitude         float64
-	Elevation         float64
-	FajrAngle         float64
-	IshaAngle         float64
-	MaghribDuration   time.Duration
-	CalculationMethod CalculationMethod
-	AsrConvention     AsrConvention
-	PreciseToSeconds  bool
-	IgnoreElevation   bool
-	TimeCorrection    TimeCorrection
-	AngleCorrection   AngleCorrection
+	Latitude           float64
+	Longitude          float64
+	Elevation          float64
+	FajrAngle          float64
+	IshaAngle          float64
+	ImsakAngle         float64
+	ImsakDuration      time.Duration
+	MaghribAngle       float64
+	MaghribDuration    time.Duration
+	CalculationMethod  CalculationMethod
+	AsrConvention      AsrConvention
+	HighLatitudeMethod HighLatitudeMethod
+	MidnightConvention MidnightConvention
+	PreciseToSeconds   bool
+	IgnoreElevation    bool
+	TimeCorrection     TimeCorrection
+	AngleCorrection    AngleCorrection
 
 	latitude       decimal.Decimal
 	longitude      decimal.Decimal
 	fajrAngle      decimal.Decimal
 	ishaAngle      decimal.Decimal
+	maghribAngle   decimal.Decimal
 	asrCoefficient decimal.Decimal
 
 	date           time.Time
 	timezone       decimal.Decimal
 	transitTime    decimal.Decimal
 	sunDeclination decimal.Decimal
+
+	tuneOffsets TimeCorrection
+
+	solarStateCache map[int64]solarState
+}
+
+// solarState is the per-day solar state that Calculate derives from a
+// julian day: the sun's transit time and declination. CalculateRange
+// memoizes these by julian day so that calculations which need another
+// day's state (e.g. Jafari midnight needing tomorrow's Fajr) don't
+// recompute it from scratch.
+type solarState struct {
+	transitTime    decimal.Decimal
+	sunDeclination decimal.Decimal
 }
 
 // Init initiates the calculator.
@@ -47,34 +76,17 @@ func (calc *Calculator) Init() *Calculator {
 	calc.latitude = decimal.NewFromFloat(calc.Latitude)
 	calc.longitude = decimal.NewFromFloat(calc.Longitude)
 
-	// Apply calculation method
-	var maghribDuration time.Duration
-	var fajrAngle, ishaAngle float64
-
-	switch calc.CalculationMethod {
-	case Default, MWL, Algerian, Diyanet:
-		fajrAngle, ishaAngle = 18, 17
-	case ISNA:
-		fajrAngle, ishaAngle = 15, 15
-	case UmmAlQura:
-		fajrAngle, maghribDuration = 18.5, 90*time.Minute
-	case Gulf:
-		fajrAngle, maghribDuration = 19.5, 90*time.Minute
-	case Karachi, France18, Tunisia:
-		fajrAngle, ishaAngle = 18, 18
-	case Egypt:
-		fajrAngle, ishaAngle = 19.5, 17.5
-	case EgyptBis, Kemenag, MUIS, JAKIM:
-		fajrAngle, ishaAngle = 20, 18
-	case UOIF:
-		fajrAngle, ishaAngle = 12, 12
-	case France15:
-		fajrAngle, ishaAngle = 15, 15
-	case Tehran:
-		fajrAngle, ishaAngle = 17.7, 14
-	case Jafari:
-		fajrAngle, ishaAngle = 16, 14
-	}
+	// Look up the calculation method's parameter bundle, then let any
+	// explicitly-set field on the calculator override it.
+	params := lookupMethod(calc.CalculationMethod)
+
+	fajrAngle := params.FajrAngle
+	ishaAngle := params.IshaAngle
+	maghribAngle := params.MaghribAngle
+	maghribDuration := time.Duration(params.MaghribMinutes) * time.Minute
+	imsakAngle := params.ImsakAngle
+	imsakDuration := time.Duration(params.ImsakMinutes) * time.Minute
+	midnightConvention := params.Midnight
 
 	if calc.FajrAngle != 0 {
 		fajrAngle = calc.FajrAngle
@@ -84,13 +96,34 @@ func (calc *Calculator) Init() *Calculator {
 		ishaAngle = calc.IshaAngle
 	}
 
+	if calc.MaghribAngle != 0 {
+		maghribAngle = calc.MaghribAngle
+	}
+
 	if calc.MaghribDuration != 0 {
 		maghribDuration = calc.MaghribDuration
 	}
 
+	if calc.ImsakAngle != 0 {
+		imsakAngle = calc.ImsakAngle
+	}
+
+	if calc.ImsakDuration != 0 {
+		imsakDuration = calc.ImsakDuration
+	}
+
+	if calc.MidnightConvention != 0 {
+		midnightConvention = calc.MidnightConvention
+	}
+
 	calc.fajrAngle = decimal.NewFromFloat(fajrAngle)
 	calc.ishaAngle = decimal.NewFromFloat(ishaAngle)
+	calc.maghribAngle = decimal.NewFromFloat(maghribAngle)
+	calc.MaghribAngle = maghribAngle
 	calc.MaghribDuration = maghribDuration
+	calc.ImsakAngle = imsakAngle
+	calc.ImsakDuration = imsakDuration
+	calc.MidnightConvention = midnightConvention
 
 	// Set asr coefficient
 	switch calc.AsrConvention {
@@ -120,17 +153,95 @@ func (calc *Calculator) SetDate(date time.Time) *Calculator {
 
 	// Calculate transit time and sun declination
 	jd := julianday.Convert(calc.date)
-	calc.transitTime = calc.getTransitTime(jd)
-	calc.sunDeclination = calc.getSunDeclination(jd)
+	state := calc.solarStateAt(jd)
+	calc.transitTime = state.transitTime
+	calc.sunDeclination = state.sunDeclination
+	return calc
+}
+
+// Tune sets per-target offsets applied as the final step of Calculate,
+// after angle/time correction and any high-latitude fallback have already
+// run. Unlike TimeCorrection, which is fed back into the convergence loop
+// and so can perturb it, Tune is a pure post-adjustment equivalent to
+// PrayTimes' tune().
+func (calc *Calculator) Tune(offsets map[Target]time.Duration) *Calculator {
+	calc.tuneOffsets = offsets
 	return calc
 }
 
+// CalculateRange returns the result of CalculateAll for every day from
+// start to end (inclusive). It memoizes transit time and sun
+// declination per julian day, so overlapping computations across days
+// (e.g. today's Jafari midnight needing tomorrow's Fajr) reuse cached
+// solar state instead of recomputing it, which makes it measurably faster
+// than calling SetDate and CalculateAll in a loop.
+func (calc Calculator) CalculateRange(start, end time.Time) map[time.Time]map[Target]time.Time {
+	calc.solarStateCache = map[int64]solarState{}
+
+	result := map[time.Time]map[Target]time.Time{}
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		calc.SetDate(date)
+		result[calc.date] = calc.CalculateAll()
+	}
+
+	return result
+}
+
+// solarStateAt returns the transit time and sun declination for jd,
+// computing and caching them on first use.
+func (calc Calculator) solarStateAt(jd decimal.Decimal) solarState {
+	key := jd.IntPart()
+	if calc.solarStateCache != nil {
+		if state, ok := calc.solarStateCache[key]; ok {
+			return state
+		}
+	}
+
+	state := solarState{
+		transitTime:    calc.getTransitTime(jd),
+		sunDeclination: calc.getSunDeclination(jd),
+	}
+
+	if calc.solarStateCache != nil {
+		calc.solarStateCache[key] = state
+	}
+
+	return state
+}
+
 // Calculate calculates time for the specified target.
 // Returns the target time and boolean to mark whether the time is available or not.
 func (calc Calculator) Calculate(target Target) (time.Time, bool) {
+	targetTime, isNA := calc.calculateUntuned(target)
+	if isNA {
+		return time.Time{}, true
+	}
+
+	if offset, exist := calc.tuneOffsets[target]; exist {
+		targetTime = targetTime.Add(offset)
+	}
+
+	return targetTime, false
+}
+
+// calculateUntuned does the actual work for Calculate, before the Tune
+// offset (if any) is applied. Internal callers that need another target's
+// time as an input, rather than as an output, call this instead of
+// Calculate so a Tune offset isn't folded in twice.
+func (calc Calculator) calculateUntuned(target Target) (time.Time, bool) {
+	// Imsak and Midnight are derived from other targets instead of being
+	// solved for directly.
+	if target == Imsak {
+		return calc.calculateImsak()
+	}
+
+	if target == Midnight {
+		return calc.calculateMidnight()
+	}
+
 	// If target is Isha and Maghrib duration is specified, just add it
 	if target == Isha && calc.MaghribDuration != 0 {
-		targetTime, isNA := calc.Calculate(Maghrib)
+		targetTime, isNA := calc.calculateUntuned(Maghrib)
 		if isNA {
 			return time.Time{}, true
 		}
@@ -146,11 +257,17 @@ func (calc Calculator) Calculate(target Target) (time.Time, bool) {
 	sunAltitude := calc.getSunAltitude(target, jd)
 
 	// Max five tries
+	isHighLatitudeTarget := target == Fajr || target == Isha
 	for i := 0; i < 5; i++ {
 		// Calculate hours to reach the target
 		dec15 := decimal.New(15, 0)
 		hourAngle, isNA := calc.getHourAngle(sunAltitude, sunDeclination)
 		if isNA {
+			if isHighLatitudeTarget && calc.HighLatitudeMethod != None {
+				if fallback, ok := calc.highLatitudeTime(target); ok {
+					return fallback, false
+				}
+			}
 			return time.Time{}, true
 		}
 
@@ -193,9 +310,142 @@ func (calc Calculator) Calculate(target Target) (time.Time, bool) {
 		}
 	}
 
+	// At high latitudes, a Fajr/Isha that technically converged can still
+	// land on the wrong side of the night (e.g. after sunrise); treat that
+	// the same as a non-convergent result.
+	if isHighLatitudeTarget && calc.HighLatitudeMethod != None {
+		if sunset, sunrise, ok := calc.nightBounds(target); ok {
+			if targetTime.Before(sunset) || targetTime.After(sunrise) {
+				if fallback, ok := calc.highLatitudeTime(target); ok {
+					targetTime = fallback
+				}
+			}
+		}
+	}
+
 	return targetTime, false
 }
 
+// sunsetSunrise returns the sunset and sunrise time of the day identified by
+// transitTime/sunDeclination, mirrored around solar transit using the
+// standard solar depression angle for the edge of the solar disc.
+func (calc Calculator) sunsetSunrise(onDate time.Time, transitTime, sunDeclination decimal.Decimal) (sunset, sunrise time.Time, ok bool) {
+	altitude := decimal.NewFromFloat(-sunriseSunsetAngle)
+	hourAngle, isNA := calc.getHourAngle(altitude, sunDeclination)
+	if isNA {
+		return time.Time{}, time.Time{}, false
+	}
+
+	dec15 := decimal.New(15, 0)
+	dated := calc
+	dated.date = onDate
+	sunset = dated.hoursToTime(transitTime.Add(hourAngle.Div(dec15)))
+	sunrise = dated.hoursToTime(transitTime.Sub(hourAngle.Div(dec15)))
+	return sunset, sunrise, true
+}
+
+// nightBounds returns the sunset/sunrise pair that bracket the night
+// relevant to target: Fajr looks at the night that is ending (yesterday's
+// sunset to today's sunrise), Isha at the night that is beginning (today's
+// sunset to tomorrow's sunrise).
+func (calc Calculator) nightBounds(target Target) (sunset, sunrise time.Time, ok bool) {
+	todaySunset, todaySunrise, ok := calc.sunsetSunriseOn(calc.date)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	adjacentDate := calc.date.AddDate(0, 0, 1)
+	if target == Fajr {
+		adjacentDate = calc.date.AddDate(0, 0, -1)
+	}
+
+	adjacentSunset, adjacentSunrise, ok := calc.sunsetSunriseOn(adjacentDate)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if target == Fajr {
+		return adjacentSunset, todaySunrise, true
+	}
+	return todaySunset, adjacentSunrise, true
+}
+
+// nearestDateSearchDays bounds how far sunsetSunriseOn searches for a day
+// with a real sunset/sunrise once it finds none on the requested date, so
+// the search can't loop indefinitely near the poles.
+const nearestDateSearchDays = 186
+
+// sunsetSunriseOn returns the sunset/sunrise time-of-day for date. If the
+// sun never actually sets or rises on date (midnight sun or polar night,
+// which happens inside the Arctic/Antarctic circles), it instead searches
+// outward day by day for the nearest date that does have a real sunset and
+// sunrise, and reapplies that date's time-of-day onto date's calendar day -
+// the same substitution PrayTimes-derived libraries use so a
+// HighLatitudeMethod fallback still has a night length to work with.
+func (calc Calculator) sunsetSunriseOn(date time.Time) (sunset, sunrise time.Time, ok bool) {
+	state := calc.solarStateAt(julianday.Convert(date))
+	if sunset, sunrise, ok := calc.sunsetSunrise(date, state.transitTime, state.sunDeclination); ok {
+		return sunset, sunrise, true
+	}
+
+	for offset := 1; offset <= nearestDateSearchDays; offset++ {
+		for _, sign := range [2]int{1, -1} {
+			nearDate := date.AddDate(0, 0, offset*sign)
+			nearState := calc.solarStateAt(julianday.Convert(nearDate))
+			if s, r, ok := calc.sunsetSunrise(nearDate, nearState.transitTime, nearState.sunDeclination); ok {
+				return applyTimeOfDay(s, date), applyTimeOfDay(r, date), true
+			}
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// applyTimeOfDay returns a time with onDate's time-of-day and dayOf's
+// calendar date, timezone-aware via dayOf's location.
+func applyTimeOfDay(onDate, dayOf time.Time) time.Time {
+	y, m, d := dayOf.Date()
+	hh, mm, ss := onDate.Clock()
+	return time.Date(y, m, d, hh, mm, ss, onDate.Nanosecond(), dayOf.Location())
+}
+
+// highLatitudeTime computes a fallback time for Fajr or Isha using the
+// configured HighLatitudeMethod, for use when the target can't be derived
+// directly from its angle, or lands outside the night.
+func (calc Calculator) highLatitudeTime(target Target) (time.Time, bool) {
+	sunset, sunrise, ok := calc.nightBounds(target)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	nightLength := sunrise.Sub(sunset)
+
+	var offset time.Duration
+	switch calc.HighLatitudeMethod {
+	case MiddleOfNight:
+		offset = nightLength / 2
+	case OneSeventhOfNight:
+		offset = nightLength / 7
+	case AngleBased:
+		// Use the resolved angle (calc.fajrAngle/calc.ishaAngle), not the
+		// exported input fields: when the angle comes from
+		// CalculationMethod rather than an explicit FajrAngle/IshaAngle
+		// override, those exported fields are still 0.
+		angle := calc.ishaAngle
+		if target == Fajr {
+			angle = calc.fajrAngle
+		}
+		offset = time.Duration(float64(nightLength) * angle.InexactFloat64() / 60)
+	default:
+		return time.Time{}, false
+	}
+
+	if target == Fajr {
+		return sunrise.Add(-offset), true
+	}
+	return sunset.Add(offset), true
+}
+
 // CalculateAll returns times for all possible targets. If the target
 // is not available, it will be omitted from result.
 func (calc Calculator) CalculateAll() map[Target]time.Time {
@@ -206,5 +456,101 @@ func (calc Calculator) CalculateAll() map[Target]time.Time {
 		}
 	}
 
+	if calc.ImsakAngle != 0 || calc.ImsakDuration != 0 {
+		if targetTime, isNA := calc.Calculate(Imsak); !isNA {
+			result[Imsak] = targetTime
+		}
+	}
+
+	if calc.MidnightConvention != 0 {
+		if targetTime, isNA := calc.Calculate(Midnight); !isNA {
+			result[Midnight] = targetTime
+		}
+	}
+
 	return result
 }
+
+// calculateImsak derives Imsak from Fajr: either ImsakDuration (or the
+// default of 10 minutes) before it, or, when ImsakAngle is set, as its own
+// solar-depression-angle target, solved in a single pass from the day's
+// transit time and declination; unlike Fajr, that pass is not refined by
+// iteration and gets no angle/time correction or high-latitude fallback.
+// Either way, Imsak is unavailable whenever Fajr is.
+func (calc Calculator) calculateImsak() (time.Time, bool) {
+	fajrTime, isNA := calc.calculateUntuned(Fajr)
+	if isNA {
+		return time.Time{}, true
+	}
+
+	if calc.ImsakAngle == 0 {
+		duration := calc.ImsakDuration
+		if duration == 0 {
+			duration = defaultImsakDuration
+		}
+		return fajrTime.Add(-duration), false
+	}
+
+	sunAltitude := decimal.NewFromFloat(-calc.ImsakAngle)
+	hourAngle, isNA := calc.getHourAngle(sunAltitude, calc.sunDeclination)
+	if isNA {
+		return time.Time{}, true
+	}
+
+	hours := calc.transitTime.Sub(hourAngle.Div(decimal.New(15, 0)))
+	return calc.hoursToTime(hours), false
+}
+
+// calculateMidnight derives Midnight as the instant halfway between today's
+// sunset and, depending on MidnightConvention, either tomorrow's sunrise
+// (Standard) or tomorrow's Fajr (Jafari).
+func (calc Calculator) calculateMidnight() (time.Time, bool) {
+	sunset, _, ok := calc.sunsetSunrise(calc.date, calc.transitTime, calc.sunDeclination)
+	if !ok {
+		return time.Time{}, true
+	}
+
+	next := calc.nextDay()
+
+	var anchor time.Time
+	if calc.MidnightConvention == MidnightJafari {
+		var anchorNA bool
+		anchor, anchorNA = next.calculateUntuned(Fajr)
+		if anchorNA {
+			return time.Time{}, true
+		}
+	} else {
+		_, anchor, ok = next.sunsetSunrise(next.date, next.transitTime, next.sunDeclination)
+		if !ok {
+			return time.Time{}, true
+		}
+	}
+
+	return midpointByJulianDay(sunset, anchor), false
+}
+
+// nextDay returns a copy of calc with the date advanced by one day and
+// transitTime/sunDeclination recomputed for it, without requiring the
+// caller to mutate its own date via SetDate.
+func (calc Calculator) nextDay() Calculator {
+	next := calc
+	next.date = calc.date.AddDate(0, 0, 1)
+	jd := julianday.Convert(next.date)
+	state := calc.solarStateAt(jd)
+	next.transitTime = state.transitTime
+	next.sunDeclination = state.sunDeclination
+	return next
+}
+
+// midpointByJulianDay returns the instant halfway between a and b, computed
+// via julian day arithmetic so the result isn't perturbed by a DST
+// transition that may fall between them.
+func midpointByJulianDay(a, b time.Time) time.Time {
+	jdA := julianday.Convert(a)
+	jdB := julianday.Convert(b)
+	midJD := jdA.Add(jdB).Div(decimal.New(2, 0))
+
+	offsetDays := midJD.Sub(jdA)
+	offsetDuration := time.Duration(offsetDays.InexactFloat64() * float64(24*time.Hour))
+	return a.Add(offsetDuration)
+}
@@ -0,0 +1,288 @@
+package prayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RadhiFadlillah/go-prayer/internal/julianday"
+)
+
+func TestHighLatitudeFallback(t *testing.T) {
+	tz := time.FixedZone("UTC+0", 0)
+	date := time.Date(2020, time.June, 21, 12, 0, 0, 0, tz)
+
+	base := Calculator{
+		Latitude:  64.1466, // Reykjavik
+		Longitude: -21.9426,
+		FajrAngle: 18,
+		IshaAngle: 17,
+	}
+
+	without := base
+	without.Init()
+	without.SetDate(date)
+	if _, isNA := without.Calculate(Fajr); !isNA {
+		t.Fatal("expected Fajr at Reykjavik in June to be unavailable without a HighLatitudeMethod")
+	}
+
+	for _, method := range []HighLatitudeMethod{MiddleOfNight, OneSeventhOfNight, AngleBased} {
+		calc := base
+		calc.HighLatitudeMethod = method
+		calc.Init()
+		calc.SetDate(date)
+
+		zuhr, isNA := calc.Calculate(Zuhr)
+		if isNA {
+			t.Fatalf("%v: expected Zuhr to be available", method)
+		}
+
+		fajr, isNA := calc.Calculate(Fajr)
+		if isNA {
+			t.Fatalf("%v: expected Fajr to fall back to a computed time", method)
+		}
+
+		isha, isNA := calc.Calculate(Isha)
+		if isNA {
+			t.Fatalf("%v: expected Isha to fall back to a computed time", method)
+		}
+
+		if !fajr.Before(zuhr) {
+			t.Errorf("%v: expected Fajr (%v) before Zuhr (%v)", method, fajr, zuhr)
+		}
+
+		if !isha.After(zuhr) {
+			t.Errorf("%v: expected Isha (%v) after Zuhr (%v)", method, isha, zuhr)
+		}
+	}
+}
+
+// TestHighLatitudeFajrUnreachable locks in the isNA path that
+// TestHighLatitudeFallback depends on: at Reykjavik in June the sun never
+// reaches 18 degrees below the horizon, so getHourAngle itself must report
+// unreachable, independent of any HighLatitudeMethod fallback built on top
+// of it.
+func TestHighLatitudeFajrUnreachable(t *testing.T) {
+	tz := time.FixedZone("UTC+0", 0)
+	date := time.Date(2020, time.June, 21, 12, 0, 0, 0, tz)
+
+	calc := Calculator{Latitude: 64.1466, Longitude: -21.9426, FajrAngle: 18, IshaAngle: 17}
+	calc.Init()
+	calc.SetDate(date)
+
+	jd := julianday.Convert(calc.date)
+	altitude := calc.getSunAltitude(Fajr, jd)
+	if _, isNA := calc.getHourAngle(altitude, calc.sunDeclination); !isNA {
+		t.Fatal("expected the -18 degree Fajr angle to be unreachable at Reykjavik in June")
+	}
+}
+
+func TestHighLatitudeFallbackTromso(t *testing.T) {
+	tz := time.FixedZone("UTC+1", 1*60*60)
+	date := time.Date(2020, time.June, 21, 12, 0, 0, 0, tz)
+
+	calc := Calculator{
+		Latitude:           69.6492, // Tromso
+		Longitude:          18.9553,
+		FajrAngle:          18,
+		IshaAngle:          17,
+		HighLatitudeMethod: MiddleOfNight,
+	}
+	calc.Init()
+	calc.SetDate(date)
+
+	fajr, isNA := calc.Calculate(Fajr)
+	if isNA {
+		t.Fatal("expected Fajr to fall back to a computed time at Tromso in June")
+	}
+
+	isha, isNA := calc.Calculate(Isha)
+	if isNA {
+		t.Fatal("expected Isha to fall back to a computed time at Tromso in June")
+	}
+
+	if !fajr.Before(isha) {
+		t.Errorf("expected Fajr (%v) before Isha (%v)", fajr, isha)
+	}
+}
+
+// TestHighLatitudeAngleBasedUsesResolvedAngle guards against AngleBased
+// scaling by the exported FajrAngle/IshaAngle fields, which stay 0 when the
+// angle comes from CalculationMethod instead of an explicit override -
+// collapsing the offset to zero and making Fajr equal sunrise.
+func TestHighLatitudeAngleBasedUsesResolvedAngle(t *testing.T) {
+	tz := time.FixedZone("UTC+0", 0)
+	date := time.Date(2020, time.June, 21, 12, 0, 0, 0, tz)
+
+	calc := Calculator{
+		Latitude:           64.1466, // Reykjavik
+		Longitude:          -21.9426,
+		CalculationMethod:  MWL,
+		HighLatitudeMethod: AngleBased,
+	}
+	calc.Init()
+	calc.SetDate(date)
+
+	_, sunrise, ok := calc.nightBounds(Fajr)
+	if !ok {
+		t.Fatal("expected night bounds to be available")
+	}
+
+	fajr, isNA := calc.Calculate(Fajr)
+	if isNA {
+		t.Fatal("expected Fajr to fall back to a computed time")
+	}
+
+	if fajr.Equal(sunrise) {
+		t.Error("AngleBased offset collapsed to zero: Fajr equals sunrise, so the resolved angle wasn't applied")
+	}
+}
+
+// TestMaghribAngle guards against MaghribAngle being stored but never
+// consulted by getSunAltitude, which would leave Jafari/Tehran Maghrib
+// identical to plain sunset.
+func TestMaghribAngle(t *testing.T) {
+	tz := time.FixedZone("UTC+3", 3*60*60)
+	date := time.Date(2024, time.March, 20, 12, 0, 0, 0, tz)
+
+	plain := Calculator{Latitude: 35.6892, Longitude: 51.3890} // Tehran
+	plain.Init()
+	plain.SetDate(date)
+	plainMaghrib, isNA := plain.Calculate(Maghrib)
+	if isNA {
+		t.Fatal("expected plain Maghrib to be available")
+	}
+
+	jafari := Calculator{Latitude: 35.6892, Longitude: 51.3890, CalculationMethod: Jafari}
+	jafari.Init()
+	jafari.SetDate(date)
+	jafariMaghrib, isNA := jafari.Calculate(Maghrib)
+	if isNA {
+		t.Fatal("expected Jafari Maghrib to be available")
+	}
+
+	if jafariMaghrib.Equal(plainMaghrib) {
+		t.Fatal("expected Jafari's 4 degree Maghrib angle to differ from plain sunset")
+	}
+
+	// A deeper depression angle (4 degrees, vs ~0.833 for plain sunset)
+	// is reached later in the evening.
+	if !jafariMaghrib.After(plainMaghrib) {
+		t.Errorf("expected Jafari Maghrib (%v) after plain sunset (%v)", jafariMaghrib, plainMaghrib)
+	}
+}
+
+func TestImsakAndMidnight(t *testing.T) {
+	tz := time.FixedZone("UTC+3", 3*60*60)
+	date := time.Date(2024, time.March, 20, 12, 0, 0, 0, tz)
+
+	calc := Calculator{
+		Latitude:           35.6892,
+		Longitude:          51.3890,
+		ImsakDuration:      10 * time.Minute,
+		MidnightConvention: MidnightStandard,
+	}
+	calc.Init()
+	calc.SetDate(date)
+
+	fajr, isNA := calc.Calculate(Fajr)
+	if isNA {
+		t.Fatal("expected Fajr to be available")
+	}
+
+	imsak, isNA := calc.Calculate(Imsak)
+	if isNA {
+		t.Fatal("expected Imsak to be available")
+	}
+
+	if got, want := fajr.Sub(imsak), 10*time.Minute; got != want {
+		t.Errorf("expected Imsak 10 minutes before Fajr, got %v", got)
+	}
+
+	maghrib, isNA := calc.Calculate(Maghrib)
+	if isNA {
+		t.Fatal("expected Maghrib to be available")
+	}
+
+	midnight, isNA := calc.Calculate(Midnight)
+	if isNA {
+		t.Fatal("expected Midnight to be available")
+	}
+
+	if !midnight.After(maghrib) {
+		t.Errorf("expected Midnight (%v) after Maghrib (%v)", midnight, maghrib)
+	}
+
+	all := calc.CalculateAll()
+	if _, ok := all[Imsak]; !ok {
+		t.Error("expected CalculateAll to include Imsak when ImsakDuration is set")
+	}
+	if _, ok := all[Midnight]; !ok {
+		t.Error("expected CalculateAll to include Midnight when MidnightConvention is set")
+	}
+}
+
+func TestCalculateRangeMatchesLoop(t *testing.T) {
+	tz := time.FixedZone("UTC+7", 7*60*60)
+	start := time.Date(2024, time.January, 1, 12, 0, 0, 0, tz)
+	end := time.Date(2024, time.January, 5, 12, 0, 0, 0, tz)
+
+	base := Calculator{
+		Latitude:           -6.2088, // Jakarta
+		Longitude:          106.8456,
+		CalculationMethod:  Kemenag,
+		MidnightConvention: MidnightStandard,
+	}
+
+	ranged := base
+	ranged.Init()
+	gotResult := ranged.CalculateRange(start, end)
+
+	looped := base
+	looped.Init()
+	wantResult := map[time.Time]map[Target]time.Time{}
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		looped.SetDate(date)
+		wantResult[looped.date] = looped.CalculateAll()
+	}
+
+	if len(gotResult) != len(wantResult) {
+		t.Fatalf("expected %d days, got %d", len(wantResult), len(gotResult))
+	}
+
+	for date, want := range wantResult {
+		got, ok := gotResult[date]
+		if !ok {
+			t.Fatalf("missing day %v in CalculateRange result", date)
+		}
+
+		if len(got) != len(want) {
+			t.Errorf("%v: expected %d targets, got %d", date, len(want), len(got))
+			continue
+		}
+
+		for target, wantTime := range want {
+			gotTime, ok := got[target]
+			if !ok {
+				t.Errorf("%v: missing target %v", date, target)
+				continue
+			}
+			if !gotTime.Equal(wantTime) {
+				t.Errorf("%v target %v: expected %v, got %v", date, target, wantTime, gotTime)
+			}
+		}
+	}
+}
+
+func TestCalculatorQibla(t *testing.T) {
+	calc := Calculator{Latitude: -6.2088, Longitude: 106.8456} // Jakarta
+
+	gotBearing := calc.Qibla()
+	wantBearing := Qibla(-6.2088, 106.8456)
+	if gotBearing != wantBearing {
+		t.Errorf("expected Calculator.Qibla() to match the package-level Qibla(), got %v want %v", gotBearing, wantBearing)
+	}
+
+	if dist := calc.QiblaDistance(); dist < 7500 || dist > 8300 {
+		t.Errorf("expected Jakarta-Kaaba distance to be roughly 7900km, got %v", dist)
+	}
+}
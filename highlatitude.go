@@ -0,0 +1,22 @@
+package prayer
+
+// HighLatitudeMethod is the method used to adjust Fajr and Isha when they
+// can't be derived directly from their angle, which commonly happens at
+// high latitudes (roughly |latitude| >= 48 degrees) where the sun never
+// gets low enough below the horizon.
+type HighLatitudeMethod int
+
+const (
+	// None disables high latitude adjustment. Fajr and/or Isha are
+	// reported as unavailable when they can't be computed directly.
+	None HighLatitudeMethod = iota
+	// MiddleOfNight sets Fajr and Isha to the midpoint of the night,
+	// i.e. halfway between sunset and the following sunrise.
+	MiddleOfNight
+	// OneSeventhOfNight sets Fajr to 6/7 and Isha to 1/7 of the night
+	// length away from sunset.
+	OneSeventhOfNight
+	// AngleBased scales the night length by the ratio between the
+	// configured Fajr/Isha angle and 60 degrees.
+	AngleBased
+)
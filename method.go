@@ -0,0 +1,117 @@
+package prayer
+
+import "sync"
+
+// CalculationMethod identifies a named bundle of calculation parameters,
+// looked up in methods by Init. The zero value is Default.
+type CalculationMethod int
+
+// MethodParams is the full set of parameters behind a CalculationMethod.
+// Any zero field simply leaves the corresponding Calculator field at its
+// own zero value, to be filled in by Calculator's own defaults.
+type MethodParams struct {
+	FajrAngle      float64
+	IshaAngle      float64
+	MaghribAngle   float64
+	MaghribMinutes int
+	ImsakAngle     float64
+	ImsakMinutes   int
+	Midnight       MidnightConvention
+}
+
+// Named calculation methods, mainly distinguished by their Fajr/Isha
+// angles and Maghrib convention.
+const (
+	Default CalculationMethod = iota
+	MWL
+	Algerian
+	Diyanet
+	ISNA
+	UmmAlQura
+	Gulf
+	Karachi
+	France18
+	Tunisia
+	Egypt
+	EgyptBis
+	Kemenag
+	MUIS
+	JAKIM
+	UOIF
+	France15
+	Tehran
+	Jafari
+)
+
+var (
+	methodsMu sync.Mutex
+
+	// methodNames maps a name passed to RegisterMethod to the
+	// CalculationMethod minted for it, so re-registering the same name
+	// updates its params instead of leaking a new entry, and callers
+	// loading presets from config can look a method up by name.
+	methodNames = map[string]CalculationMethod{}
+
+	methods = map[CalculationMethod]MethodParams{
+		Default:   {FajrAngle: 18, IshaAngle: 17},
+		MWL:       {FajrAngle: 18, IshaAngle: 17},
+		Algerian:  {FajrAngle: 18, IshaAngle: 17},
+		Diyanet:   {FajrAngle: 18, IshaAngle: 17},
+		ISNA:      {FajrAngle: 15, IshaAngle: 15},
+		UmmAlQura: {FajrAngle: 18.5, MaghribMinutes: 90},
+		Gulf:      {FajrAngle: 19.5, MaghribMinutes: 90},
+		Karachi:   {FajrAngle: 18, IshaAngle: 18},
+		France18:  {FajrAngle: 18, IshaAngle: 18},
+		Tunisia:   {FajrAngle: 18, IshaAngle: 18},
+		Egypt:     {FajrAngle: 19.5, IshaAngle: 17.5},
+		EgyptBis:  {FajrAngle: 20, IshaAngle: 18},
+		Kemenag:   {FajrAngle: 20, IshaAngle: 18},
+		MUIS:      {FajrAngle: 20, IshaAngle: 18},
+		JAKIM:     {FajrAngle: 20, IshaAngle: 18},
+		UOIF:      {FajrAngle: 12, IshaAngle: 12},
+		France15:  {FajrAngle: 15, IshaAngle: 15},
+		Tehran:    {FajrAngle: 17.7, IshaAngle: 14, MaghribAngle: 4.5},
+		Jafari:    {FajrAngle: 16, IshaAngle: 14, MaghribAngle: 4, Midnight: MidnightJafari},
+	}
+
+	// nextMethod is the CalculationMethod value handed out by the next
+	// call to RegisterMethod.
+	nextMethod = Jafari + 1
+)
+
+// lookupMethod returns the MethodParams registered for method, or the zero
+// value if none is registered.
+func lookupMethod(method CalculationMethod) MethodParams {
+	methodsMu.Lock()
+	defer methodsMu.Unlock()
+	return methods[method]
+}
+
+// RegisterMethod registers a custom calculation method under name, e.g.
+// one loaded from a configuration file, and returns the CalculationMethod
+// to set on Calculator.CalculationMethod to use it. Registering the same
+// name again updates its params in place rather than minting a new method.
+func RegisterMethod(name string, params MethodParams) CalculationMethod {
+	methodsMu.Lock()
+	defer methodsMu.Unlock()
+
+	method, exist := methodNames[name]
+	if !exist {
+		method = nextMethod
+		nextMethod++
+		methodNames[name] = method
+	}
+
+	methods[method] = params
+	return method
+}
+
+// MethodByName returns the CalculationMethod previously registered under
+// name via RegisterMethod, if any.
+func MethodByName(name string) (CalculationMethod, bool) {
+	methodsMu.Lock()
+	defer methodsMu.Unlock()
+
+	method, exist := methodNames[name]
+	return method, exist
+}
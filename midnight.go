@@ -0,0 +1,13 @@
+package prayer
+
+// MidnightConvention is the convention used to compute the Midnight target.
+type MidnightConvention int
+
+const (
+	// MidnightStandard sets Midnight to the midpoint between today's
+	// sunset and tomorrow's sunrise.
+	MidnightStandard MidnightConvention = iota + 1
+	// MidnightJafari sets Midnight to the midpoint between today's
+	// sunset and tomorrow's Fajr.
+	MidnightJafari
+)
@@ -0,0 +1,21 @@
+package prayer
+
+import "github.com/RadhiFadlillah/go-prayer/qibla"
+
+// Qibla returns the initial great-circle bearing (degrees clockwise from
+// true north) from lat/lon toward the Kaaba.
+func Qibla(lat, lon float64) float64 {
+	return qibla.Bearing(lat, lon, qibla.Kaaba)
+}
+
+// Qibla returns the qibla bearing (degrees clockwise from true north) from
+// this calculator's location toward the Kaaba.
+func (calc Calculator) Qibla() float64 {
+	return Qibla(calc.Latitude, calc.Longitude)
+}
+
+// QiblaDistance returns the great-circle distance, in kilometers, from this
+// calculator's location to the Kaaba.
+func (calc Calculator) QiblaDistance() float64 {
+	return qibla.Distance(calc.Latitude, calc.Longitude, qibla.Kaaba)
+}
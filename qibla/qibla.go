@@ -0,0 +1,59 @@
+// Package qibla computes the qibla bearing and distance toward a target
+// point, defaulting to the Kaaba.
+package qibla
+
+import "math"
+
+// Point is a geographic point, used as a qibla target.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Kaaba is the default qibla target: the Kaaba in Mecca.
+var Kaaba = Point{Latitude: 21.4225, Longitude: 39.8262}
+
+const earthRadiusKM = 6371.0
+
+// negligibleDistanceKM is the distance below which an origin is considered
+// to already be at the target, so users near/at the Kaaba get a sensible
+// bearing instead of one derived from floating point noise.
+const negligibleDistanceKM = 0.005
+
+// Bearing returns the initial great-circle bearing (degrees clockwise from
+// true north) from (lat, lon) toward target, normalized to [0, 360). If
+// (lat, lon) is within a few meters of target, it returns 0.
+func Bearing(lat, lon float64, target Point) float64 {
+	if Distance(lat, lon, target) < negligibleDistanceKM {
+		return 0
+	}
+
+	phi1 := toRadians(lat)
+	phi2 := toRadians(target.Latitude)
+	deltaLambda := toRadians(target.Longitude - lon)
+
+	y := math.Sin(deltaLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(deltaLambda)
+
+	degrees := toDegrees(math.Atan2(y, x))
+	return math.Mod(degrees+360, 360)
+}
+
+// Distance returns the great-circle distance, in kilometers, from (lat,
+// lon) to target, via the haversine formula.
+func Distance(lat, lon float64, target Point) float64 {
+	phi1 := toRadians(lat)
+	phi2 := toRadians(target.Latitude)
+	deltaPhi := toRadians(target.Latitude - lat)
+	deltaLambda := toRadians(target.Longitude - lon)
+
+	a := math.Sin(deltaPhi/2)*math.Sin(deltaPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(deltaLambda/2)*math.Sin(deltaLambda/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return earthRadiusKM * c
+}
+
+func toRadians(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+func toDegrees(radians float64) float64 { return radians * 180 / math.Pi }
@@ -0,0 +1,47 @@
+package qibla
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBearingAtKaabaIsZero(t *testing.T) {
+	got := Bearing(Kaaba.Latitude, Kaaba.Longitude, Kaaba)
+	if got != 0 {
+		t.Errorf("expected bearing at the Kaaba to be 0, got %v", got)
+	}
+}
+
+func TestBearingJakartaToKaaba(t *testing.T) {
+	got := Bearing(-6.2088, 106.8456, Kaaba)
+	if got < 0 || got >= 360 {
+		t.Fatalf("expected bearing in [0, 360), got %v", got)
+	}
+
+	// Mecca lies roughly west-northwest of Jakarta.
+	if got < 270 || got > 320 {
+		t.Errorf("expected Jakarta's qibla bearing to be roughly WNW, got %v", got)
+	}
+}
+
+func TestDistanceToKaabaIsZero(t *testing.T) {
+	got := Distance(Kaaba.Latitude, Kaaba.Longitude, Kaaba)
+	if math.Abs(got) > 1e-9 {
+		t.Errorf("expected distance from the Kaaba to itself to be 0, got %v", got)
+	}
+}
+
+func TestDistanceJakartaToKaaba(t *testing.T) {
+	got := Distance(-6.2088, 106.8456, Kaaba)
+	if got < 7500 || got > 8300 {
+		t.Errorf("expected Jakarta-Kaaba distance to be roughly 7900km, got %v", got)
+	}
+}
+
+func TestBearingNegligibleDistanceIsZero(t *testing.T) {
+	// A few meters from the Kaaba, well under negligibleDistanceKM.
+	got := Bearing(Kaaba.Latitude+0.00001, Kaaba.Longitude, Kaaba)
+	if got != 0 {
+		t.Errorf("expected bearing within negligibleDistanceKM of the Kaaba to be 0, got %v", got)
+	}
+}
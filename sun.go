@@ -0,0 +1,136 @@
+package prayer
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// dtr converts degrees to radians.
+func dtr(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+// rtd converts radians to degrees.
+func rtd(radians float64) float64 { return radians * 180 / math.Pi }
+
+// fixAngle normalizes an angle in degrees to [0, 360).
+func fixAngle(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+// fixHour normalizes an hour value to [0, 24).
+func fixHour(hour float64) float64 {
+	hour = math.Mod(hour, 24)
+	if hour < 0 {
+		hour += 24
+	}
+	return hour
+}
+
+// sunPosition returns the sun's apparent ecliptic longitude, the equation
+// of time (in hours) and the obliquity of the ecliptic (in degrees) for
+// the given julian day, using the low precision solar position formulas
+// from the Astronomical Almanac.
+func sunPosition(jd float64) (eclipticLongitude, equationOfTime, obliquity float64) {
+	d := jd - 2451545.0
+	g := fixAngle(357.529 + 0.98560028*d)
+	q := fixAngle(280.459 + 0.98564736*d)
+	l := fixAngle(q + 1.915*math.Sin(dtr(g)) + 0.020*math.Sin(dtr(2*g)))
+
+	e := 23.439 - 0.00000036*d
+	ra := rtd(math.Atan2(math.Cos(dtr(e))*math.Sin(dtr(l)), math.Cos(dtr(l)))) / 15
+	eqt := fixHour(q/15-fixHour(ra)+12) - 12
+
+	return l, eqt, e
+}
+
+// getSunDeclination returns the sun's declination, in degrees, for jd.
+func (calc Calculator) getSunDeclination(jd decimal.Decimal) decimal.Decimal {
+	l, _, e := sunPosition(jd.InexactFloat64())
+	decl := rtd(math.Asin(math.Sin(dtr(e)) * math.Sin(dtr(l))))
+	return decimal.NewFromFloat(decl)
+}
+
+// getTransitTime returns the solar transit (Zuhr) time, in hours, for jd,
+// before the longitude/timezone correction applied by hoursToTime.
+func (calc Calculator) getTransitTime(jd decimal.Decimal) decimal.Decimal {
+	_, eqt, _ := sunPosition(jd.InexactFloat64())
+	return decimal.NewFromFloat(12 - eqt)
+}
+
+// getSunAltitude returns the solar altitude (degrees, negative below the
+// horizon) that target is defined to occur at.
+func (calc Calculator) getSunAltitude(target Target, jd decimal.Decimal) decimal.Decimal {
+	switch target {
+	case Fajr:
+		return calc.fajrAngle.Neg()
+	case Isha:
+		return calc.ishaAngle.Neg()
+	case Maghrib:
+		if !calc.maghribAngle.IsZero() {
+			return calc.maghribAngle.Neg()
+		}
+		return decimal.NewFromFloat(-sunriseSunsetAngle)
+	case Asr:
+		return calc.asrAltitude(calc.getSunDeclination(jd))
+	default:
+		return decimal.Zero
+	}
+}
+
+// asrAltitude returns the solar altitude at which the Asr shadow condition
+// set by AsrConvention (via asrCoefficient) is met.
+func (calc Calculator) asrAltitude(declination decimal.Decimal) decimal.Decimal {
+	lat := calc.Latitude
+	decl := declination.InexactFloat64()
+	t := calc.asrCoefficient.InexactFloat64()
+
+	x := t + math.Tan(dtr(math.Abs(lat-decl)))
+	angle := rtd(math.Atan(1 / x))
+	return decimal.NewFromFloat(angle)
+}
+
+// getHourAngle returns the hour angle, in degrees, between solar transit
+// and the moment the sun reaches altitude at the given declination. The
+// second return value is true if the sun never reaches that altitude on
+// this day (e.g. Fajr/Isha at high latitudes in summer).
+func (calc Calculator) getHourAngle(altitude, declination decimal.Decimal) (decimal.Decimal, bool) {
+	lat := dtr(calc.Latitude)
+	decl := dtr(declination.InexactFloat64())
+	alt := dtr(altitude.InexactFloat64())
+
+	denominator := math.Cos(lat) * math.Cos(decl)
+	if denominator == 0 {
+		return decimal.Zero, true
+	}
+
+	cosH := (math.Sin(alt) - math.Sin(lat)*math.Sin(decl)) / denominator
+	if cosH < -1 || cosH > 1 {
+		return decimal.Zero, true
+	}
+
+	return decimal.NewFromFloat(rtd(math.Acos(cosH))), false
+}
+
+// hoursToTime converts an hours-of-day value (in universal time, as if at
+// the prime meridian) into a wall-clock time.Time on calc.date, applying this
+// calculator's timezone and longitude correction.
+func (calc Calculator) hoursToTime(hours decimal.Decimal) time.Time {
+	localHours := hours.Add(calc.timezone).Sub(calc.longitude.Div(decimal.New(15, 0)))
+
+	y, m, d := calc.date.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, calc.date.Location())
+
+	seconds := localHours.Mul(decimal.New(3600, 0))
+	if calc.PreciseToSeconds {
+		seconds = seconds.Round(0)
+	} else {
+		seconds = seconds.Div(decimal.New(60, 0)).Round(0).Mul(decimal.New(60, 0))
+	}
+
+	return midnight.Add(time.Duration(seconds.IntPart()) * time.Second)
+}
@@ -0,0 +1,22 @@
+package prayer
+
+// Target is the type of prayer time that can be calculated.
+type Target int
+
+// Available targets, ordered by time of day.
+const (
+	// Imsak marks the recommended start of fasting, shortly before Fajr.
+	Imsak Target = iota
+	// Fajr is the dawn prayer.
+	Fajr
+	// Zuhr is the midday prayer.
+	Zuhr
+	// Asr is the afternoon prayer.
+	Asr
+	// Maghrib is the sunset prayer.
+	Maghrib
+	// Isha is the night prayer.
+	Isha
+	// Midnight marks the end of Isha's time and the start of Tahajjud.
+	Midnight
+)